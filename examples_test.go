@@ -5,27 +5,26 @@ import (
 	"log"
 	"net/http"
 
-	"github.com/rakyll/trace2"
-	"github.com/rakyll/trace2/gcp"
+	trace "github.com/rakyll/trace2"
+	"github.com/rakyll/trace2/otlp"
 )
 
-func ExampleNewTrace() {
-	c, err := gcp.NewClient(context.Background(), "project-id")
+func ExampleWithSpan() {
+	c, err := otlp.NewClient(context.Background(), "otel-collector:4317", otlp.WithInsecure())
 	if err != nil {
 		log.Fatal(err)
 	}
-	trace.Configure(c)
+	defer c.Close()
 
-	span, finish := trace.NewSpan("/foo")
+	ctx := trace.WithClient(context.Background(), c)
+	ctx, finish := trace.WithSpan(ctx, "/foo")
 	defer finish()
 
-	span.Annotate("hello", []byte("error happened"))
+	trace.SetLabel(ctx, "hello", "error happened")
 
 	req, _ := http.NewRequest("GET", "http://google.com/", nil)
-	req, err = span.ToHTTPReq(req)
-	if err != nil {
-		log.Fatal(err)
-	}
+	req = trace.ToHTTPReq(ctx, req)
 
 	// do the request
+	_ = req
 }
@@ -0,0 +1,112 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+)
+
+// spanContextKey is the context key used to stash a SpanContext extracted
+// from an inbound request so that a Client's NewSpan implementation can
+// treat it as the parent of the span it creates.
+var spanContextKey = contextKey("trace-span-context")
+
+// ContextWithSpanContext returns a copy of ctx carrying sc. Client
+// implementations that support propagation should check
+// SpanContextFromContext in NewSpan and use sc as the parent of the span
+// they create.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey, sc)
+}
+
+// SpanContextFromContext returns the SpanContext previously attached to ctx
+// by ContextWithSpanContext, if any.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey).(SpanContext)
+	return sc, ok
+}
+
+// HTTPHandler wraps next with a server-kind span named after the request
+// path. The span is extracted from the incoming request using the
+// Propagator configured on the context (see WithPropagator), so that it
+// continues the caller's trace rather than starting a new one.
+//
+// If the request's context carries no trace client, HTTPHandler simply
+// calls next.
+func HTTPHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, finish := startHTTPSpan(r)
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		defer func() {
+			SetLabel(ctx, "http.status_code", rec.status)
+			finish()
+		}()
+		next.ServeHTTP(rec, r.WithContext(ctx))
+	})
+}
+
+// HTTPHandlerFunc is the func(http.ResponseWriter, *http.Request) form of
+// HTTPHandler, for callers that register handlers as plain functions.
+func HTTPHandlerFunc(next func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return HTTPHandler(http.HandlerFunc(next)).ServeHTTP
+}
+
+func startHTTPSpan(r *http.Request) (context.Context, FinishFunc) {
+	ctx := r.Context()
+	if p := propagatorFromContext(ctx); p != nil {
+		if sc, ok := p.Extract(r.Header); ok {
+			ctx = ContextWithSpanContext(ctx, sc)
+			// Honor the upstream sampling decision so every span in this
+			// trace, local and remote, shares the same fate.
+			ctx = context.WithValue(ctx, sampledKey, sc.Sampled)
+		}
+	}
+	ctx, finish := WithSpan(ctx, r.URL.Path)
+	SetKind(ctx, SpanKindServer)
+	SetLabel(ctx, "http.method", r.Method)
+	SetLabel(ctx, "http.path", r.URL.Path)
+	SetLabel(ctx, "http.user_agent", r.UserAgent())
+	return ctx, finish
+}
+
+// ToHTTPReq returns a shallow copy of req carrying the current span's
+// context in its headers, injected with the Propagator configured on ctx
+// (see WithPropagator), so that the service handling req can continue this
+// trace.
+//
+// If ctx carries no trace client, no propagator, or a Client that doesn't
+// implement SpanContextProvider, ToHTTPReq returns req unchanged.
+func ToHTTPReq(ctx context.Context, req *http.Request) *http.Request {
+	p := propagatorFromContext(ctx)
+	if p == nil {
+		return req
+	}
+	info := infoFromContext(ctx)
+	if info == nil {
+		return req
+	}
+	scp, ok := info.client.(SpanContextProvider)
+	if !ok {
+		return req
+	}
+	sc, ok := scp.SpanContext(ctx)
+	if !ok {
+		return req
+	}
+	sc.Sampled = Sampled(ctx)
+
+	req = req.Clone(req.Context())
+	p.Inject(sc, req.Header)
+	return req
+}
+
+// statusRecorder wraps an http.ResponseWriter to capture the status code
+// written by the handler, defaulting to 200 if WriteHeader is never called.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
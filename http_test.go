@@ -0,0 +1,183 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakePropagator is a trivial Propagator that round-trips a SpanContext
+// through a single header, for tests that don't need a real wire format.
+type fakePropagator struct{}
+
+const fakePropagatorHeader = "X-Fake-Trace"
+
+func (fakePropagator) Inject(sc SpanContext, h http.Header) {
+	if !sc.IsValid() {
+		return
+	}
+	sampled := "0"
+	if sc.Sampled {
+		sampled = "1"
+	}
+	h.Set(fakePropagatorHeader, string(sc.TraceID[:])+"|"+string(sc.SpanID[:])+"|"+sampled)
+}
+
+func (fakePropagator) Extract(h http.Header) (SpanContext, bool) {
+	v := h.Get(fakePropagatorHeader)
+	if v == "" {
+		return SpanContext{}, false
+	}
+	var sc SpanContext
+	copy(sc.TraceID[:], v[:16])
+	copy(sc.SpanID[:], v[17:25])
+	sc.Sampled = v[26:] == "1"
+	return sc, true
+}
+
+// scClient is a fakeClient that also records the parent SpanContext it saw
+// (if any) when a new span was created, and implements SpanContextProvider
+// so ToHTTPReq can inject its span's identifiers.
+type scClient struct {
+	fakeClient
+	gotParent SpanContext
+	sawParent bool
+}
+
+func (c *scClient) NewSpan(ctx context.Context, name string) context.Context {
+	if sc, ok := SpanContextFromContext(ctx); ok {
+		c.gotParent = sc
+		c.sawParent = true
+	}
+	return c.fakeClient.NewSpan(ctx, name)
+}
+
+func (c *scClient) SpanContext(ctx context.Context) (SpanContext, bool) {
+	var sc SpanContext
+	sc.TraceID = [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+	sc.SpanID = [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	sc.Sampled = Sampled(ctx)
+	return sc, true
+}
+
+func parentSpanContext() SpanContext {
+	var sc SpanContext
+	sc.TraceID = [16]byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}
+	sc.SpanID = [8]byte{8, 8, 8, 8, 8, 8, 8, 8}
+	sc.Sampled = true
+	return sc
+}
+
+func TestHTTPHandlerHonorsExtractedParentAndSetsServerKind(t *testing.T) {
+	c := &scClient{}
+	ctx := WithClient(context.Background(), c, WithPropagator(fakePropagator{}))
+
+	var sawKind SpanKind
+	handler := HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawKind = infoFromContext(r.Context()).kind
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	req := httptest.NewRequest("GET", "/hello?x=1", nil).WithContext(ctx)
+	req.Header.Set("User-Agent", "test-agent")
+	fakePropagator{}.Inject(parentSpanContext(), req.Header)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if !c.sawParent {
+		t.Fatal("NewSpan never observed an extracted parent SpanContext")
+	}
+	if c.gotParent.TraceID != parentSpanContext().TraceID {
+		t.Errorf("parent TraceID = %x, want %x", c.gotParent.TraceID, parentSpanContext().TraceID)
+	}
+	if sawKind != SpanKindServer {
+		t.Errorf("span Kind = %v, want %v", sawKind, SpanKindServer)
+	}
+	if c.finished != 1 {
+		t.Errorf("finished = %d, want 1", c.finished)
+	}
+}
+
+func TestHTTPHandlerSetsRequestLabelsAndStatus(t *testing.T) {
+	c := &fakeClient{}
+	ctx := WithClient(context.Background(), c)
+
+	var labels map[string]interface{}
+	handler := HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		labels = infoFromContext(r.Context()).labels
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	req := httptest.NewRequest("POST", "/widgets", nil).WithContext(ctx)
+	req.Header.Set("User-Agent", "test-agent")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if labels["http.method"] != "POST" || labels["http.path"] != "/widgets" || labels["http.user_agent"] != "test-agent" {
+		t.Errorf("labels = %+v, want method/path/user_agent set from the request", labels)
+	}
+	if rec.Code != http.StatusTeapot {
+		t.Errorf("response status = %d, want %d", rec.Code, http.StatusTeapot)
+	}
+}
+
+func TestHTTPHandlerFuncWrapsPlainFunc(t *testing.T) {
+	called := false
+	h := HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+
+	req := httptest.NewRequest("GET", "/", nil)
+	h(httptest.NewRecorder(), req)
+
+	if !called {
+		t.Error("wrapped func was never called")
+	}
+}
+
+func TestToHTTPReqInjectsSpanContextAndSampledBit(t *testing.T) {
+	c := &scClient{}
+	ctx := WithClient(context.Background(), c, WithPropagator(fakePropagator{}))
+	ctx, finish := WithSpan(ctx, "op")
+	defer finish()
+
+	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	req = ToHTTPReq(ctx, req)
+
+	sc, ok := fakePropagator{}.Extract(req.Header)
+	if !ok {
+		t.Fatal("ToHTTPReq didn't inject a SpanContext into the request")
+	}
+	want, _ := c.SpanContext(ctx)
+	if sc.TraceID != want.TraceID || sc.SpanID != want.SpanID {
+		t.Errorf("injected SpanContext = %+v, want %+v", sc, want)
+	}
+	if !sc.Sampled {
+		t.Error("injected Sampled = false, want true")
+	}
+}
+
+func TestToHTTPReqReturnsRequestUnchangedWithoutPropagator(t *testing.T) {
+	c := &scClient{}
+	ctx := WithClient(context.Background(), c)
+	ctx, finish := WithSpan(ctx, "op")
+	defer finish()
+
+	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	got := ToHTTPReq(ctx, req)
+
+	if got != req {
+		t.Error("ToHTTPReq returned a different request when no Propagator is configured")
+	}
+}
+
+func TestStatusRecorderDefaultsTo200(t *testing.T) {
+	rec := &statusRecorder{ResponseWriter: httptest.NewRecorder(), status: http.StatusOK}
+	if rec.status != http.StatusOK {
+		t.Errorf("default status = %d, want 200", rec.status)
+	}
+	rec.WriteHeader(http.StatusNotFound)
+	if rec.status != http.StatusNotFound {
+		t.Errorf("status after WriteHeader = %d, want 404", rec.status)
+	}
+}
@@ -0,0 +1,75 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestKeyvalsToAttrs(t *testing.T) {
+	attrs := keyvalsToAttrs([]interface{}{"a", 1, "b", "two", 3, "dangling-int-key"})
+	if attrs["a"] != 1 || attrs["b"] != "two" {
+		t.Errorf("attrs = %+v, want a=1 b=two", attrs)
+	}
+	if attrs["3"] != "dangling-int-key" {
+		t.Errorf("attrs = %+v, want non-string key 3 stringified", attrs)
+	}
+}
+
+func TestKeyvalsToAttrsDropsTrailingUnpairedKey(t *testing.T) {
+	attrs := keyvalsToAttrs([]interface{}{"a", 1, "dangling"})
+	if len(attrs) != 1 {
+		t.Errorf("attrs = %+v, want exactly one pair", attrs)
+	}
+}
+
+// fakeLogger records every call made to Log.
+type fakeLogger struct {
+	calls [][]interface{}
+	err   error
+}
+
+func (f *fakeLogger) Log(ctx context.Context, keyvals ...interface{}) error {
+	f.calls = append(f.calls, keyvals)
+	return f.err
+}
+
+func TestLogForwardsToConfiguredLoggerAndRecordsEvent(t *testing.T) {
+	logger := &fakeLogger{}
+	ctx := WithClient(context.Background(), &fakeClient{}, WithLogger(logger))
+	ctx, finish := WithSpan(ctx, "op")
+	defer finish()
+
+	if err := Log(ctx, "msg", "hello"); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+
+	if len(logger.calls) != 1 {
+		t.Fatalf("logger received %d calls, want 1", len(logger.calls))
+	}
+
+	info := infoFromContext(ctx)
+	if len(info.events) != 1 || info.events[0].Name != "log" {
+		t.Errorf("events = %+v, want a single \"log\" event", info.events)
+	}
+	if info.events[0].Attrs["msg"] != "hello" {
+		t.Errorf("event attrs = %+v, want msg=hello", info.events[0].Attrs)
+	}
+}
+
+func TestLogReturnsLoggerError(t *testing.T) {
+	logger := &fakeLogger{err: errors.New("boom")}
+	ctx := WithClient(context.Background(), &fakeClient{}, WithLogger(logger))
+	ctx, finish := WithSpan(ctx, "op")
+	defer finish()
+
+	if err := Log(ctx, "msg", "hello"); err == nil {
+		t.Fatal("Log: got nil error, want the logger's error")
+	}
+}
+
+func TestLogWithoutClientIsNoop(t *testing.T) {
+	if err := Log(context.Background(), "msg", "hello"); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+}
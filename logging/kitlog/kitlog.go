@@ -0,0 +1,26 @@
+// Package kitlog adapts a go-kit/log.Logger to trace.Logger.
+package kitlog
+
+import (
+	"context"
+
+	kitlog "github.com/go-kit/log"
+	trace "github.com/rakyll/trace2"
+)
+
+// Logger forwards trace.Log calls to an underlying go-kit log.Logger,
+// keeping its alternating key-value style.
+type Logger struct {
+	l kitlog.Logger
+}
+
+// New returns a trace.Logger that writes through l.
+func New(l kitlog.Logger) *Logger {
+	return &Logger{l: l}
+}
+
+func (a *Logger) Log(ctx context.Context, keyvals ...interface{}) error {
+	return a.l.Log(keyvals...)
+}
+
+var _ trace.Logger = (*Logger)(nil)
@@ -0,0 +1,24 @@
+package kitlog
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	kitlog "github.com/go-kit/log"
+)
+
+func TestLoggerForwardsKeyvals(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(kitlog.NewLogfmtLogger(&buf))
+
+	if err := l.Log(context.Background(), "trace_id", "abc", "msg", "hello"); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+
+	got := strings.TrimSpace(buf.String())
+	if got != "trace_id=abc msg=hello" {
+		t.Errorf("logged line = %q, want %q", got, "trace_id=abc msg=hello")
+	}
+}
@@ -0,0 +1,27 @@
+// Package slog adapts a log/slog.Logger to trace.Logger.
+package slog
+
+import (
+	"context"
+	"log/slog"
+
+	trace "github.com/rakyll/trace2"
+)
+
+// Logger forwards trace.Log calls to an underlying *slog.Logger at info
+// level.
+type Logger struct {
+	l *slog.Logger
+}
+
+// New returns a trace.Logger that writes through l.
+func New(l *slog.Logger) *Logger {
+	return &Logger{l: l}
+}
+
+func (a *Logger) Log(ctx context.Context, keyvals ...interface{}) error {
+	a.l.Log(ctx, slog.LevelInfo, "", keyvals...)
+	return nil
+}
+
+var _ trace.Logger = (*Logger)(nil)
@@ -0,0 +1,26 @@
+package slog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLoggerForwardsKeyvalsAtInfoLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	if err := l.Log(context.Background(), "trace_id", "abc"); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+
+	got := buf.String()
+	if !strings.Contains(got, "level=INFO") {
+		t.Errorf("logged line = %q, want level=INFO", got)
+	}
+	if !strings.Contains(got, "trace_id=abc") {
+		t.Errorf("logged line = %q, want trace_id=abc", got)
+	}
+}
@@ -0,0 +1,33 @@
+// Package stdlog adapts the standard library's log.Logger to trace.Logger.
+package stdlog
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	trace "github.com/rakyll/trace2"
+)
+
+// Logger forwards trace.Log calls to an underlying *log.Logger, formatting
+// the key-value pairs as "key=value" fields.
+type Logger struct {
+	l *log.Logger
+}
+
+// New returns a trace.Logger that writes through l.
+func New(l *log.Logger) *Logger {
+	return &Logger{l: l}
+}
+
+func (a *Logger) Log(ctx context.Context, keyvals ...interface{}) error {
+	fields := make([]string, 0, len(keyvals)/2)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		fields = append(fields, fmt.Sprintf("%v=%v", keyvals[i], keyvals[i+1]))
+	}
+	a.l.Println(strings.Join(fields, " "))
+	return nil
+}
+
+var _ trace.Logger = (*Logger)(nil)
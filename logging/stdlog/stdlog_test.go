@@ -0,0 +1,37 @@
+package stdlog
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestLoggerFormatsKeyvalsAsFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(log.New(&buf, "", 0))
+
+	if err := l.Log(context.Background(), "trace_id", "abc", "msg", "hello"); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+
+	got := strings.TrimSpace(buf.String())
+	if got != "trace_id=abc msg=hello" {
+		t.Errorf("logged line = %q, want %q", got, "trace_id=abc msg=hello")
+	}
+}
+
+func TestLoggerDropsTrailingUnpairedKey(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(log.New(&buf, "", 0))
+
+	if err := l.Log(context.Background(), "k", "v", "dangling"); err != nil {
+		t.Fatalf("Log: %v", err)
+	}
+
+	got := strings.TrimSpace(buf.String())
+	if got != "k=v" {
+		t.Errorf("logged line = %q, want %q", got, "k=v")
+	}
+}
@@ -0,0 +1,240 @@
+package trace
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrorMode controls how a MultiClient combines errors returned by its
+// underlying Clients.
+type ErrorMode int
+
+const (
+	// ContinueOnError waits for every underlying Client to respond to a
+	// call and combines any errors into one, so a single failing backend
+	// doesn't hide results from the others. It is the default.
+	ContinueOnError ErrorMode = iota
+	// FailFast returns as soon as any one underlying Client reports an
+	// error, without waiting for the rest to respond.
+	FailFast
+)
+
+// MultiOption configures a Client returned by MultiClient.
+type MultiOption func(*multiClient)
+
+// WithErrorMode sets how a MultiClient combines errors from its underlying
+// Clients. The default is ContinueOnError.
+func WithErrorMode(m ErrorMode) MultiOption {
+	return func(mc *multiClient) {
+		mc.mode = m
+	}
+}
+
+// WithCallTimeout bounds how long a MultiClient waits for its underlying
+// Clients to respond to a single call. A Client that doesn't respond
+// within the timeout is dropped from that call's result and doesn't hold
+// up the others. The default, zero, means no timeout.
+func WithCallTimeout(d time.Duration) MultiOption {
+	return func(mc *multiClient) {
+		mc.timeout = d
+	}
+}
+
+// MultiClient returns a Client that fans every call out to each of clients
+// concurrently. This lets a single trace be shipped to several tracing
+// backends at once -- for example while migrating off one backend, or to
+// add an in-memory recorder for tests alongside a real one.
+func MultiClient(clients []Client, opts ...MultiOption) Client {
+	mc := &multiClient{clients: clients}
+	for _, opt := range opts {
+		opt(mc)
+	}
+	return mc
+}
+
+type multiClient struct {
+	clients []Client
+	mode    ErrorMode
+	timeout time.Duration
+}
+
+type multiSpanKeyType struct{}
+
+var multiSpanKey multiSpanKeyType
+
+// spanContexts holds the per-child-client contexts produced by NewSpan. It
+// is shared (via a pointer stashed in the context) between the goroutines
+// NewSpan starts and any later Finish/Info call, so that a client whose
+// NewSpan call is still in flight when the call timeout fires isn't
+// dropped: once it eventually completes, its context becomes visible to
+// whichever Finish/Info call reads the map next instead of being lost.
+type spanContexts struct {
+	mu   sync.Mutex
+	ctxs map[Client]context.Context
+}
+
+func newSpanContexts(fallback context.Context, clients []Client) *spanContexts {
+	ctxs := make(map[Client]context.Context, len(clients))
+	for _, c := range clients {
+		ctxs[c] = fallback
+	}
+	return &spanContexts{ctxs: ctxs}
+}
+
+func (s *spanContexts) set(c Client, ctx context.Context) {
+	s.mu.Lock()
+	s.ctxs[c] = ctx
+	s.mu.Unlock()
+}
+
+func (s *spanContexts) snapshot() map[Client]context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[Client]context.Context, len(s.ctxs))
+	for c, ctx := range s.ctxs {
+		out[c] = ctx
+	}
+	return out
+}
+
+func (mc *multiClient) NewSpan(ctx context.Context, name string) context.Context {
+	spans := newSpanContexts(ctx, mc.clients)
+	done := make(chan Client, len(mc.clients))
+	for _, c := range mc.clients {
+		c := c
+		go func() {
+			spans.set(c, c.NewSpan(ctx, name))
+			done <- c
+		}()
+	}
+
+	deadline := mc.deadline()
+	for i := 0; i < len(mc.clients); i++ {
+		select {
+		case <-done:
+		case <-deadline:
+			return context.WithValue(ctx, multiSpanKey, spans)
+		}
+	}
+	return context.WithValue(ctx, multiSpanKey, spans)
+}
+
+func (mc *multiClient) Info(ctx context.Context) []byte {
+	children := mc.childContexts(ctx)
+	done := make(chan []byte, len(children))
+	for c, cctx := range children {
+		c, cctx := c, cctx
+		go func() {
+			done <- c.Info(cctx)
+		}()
+	}
+
+	var info []byte
+	deadline := mc.deadline()
+	for i := 0; i < len(children); i++ {
+		select {
+		case b := <-done:
+			if info == nil && b != nil {
+				info = b
+			}
+		case <-deadline:
+			return info
+		}
+	}
+	return info
+}
+
+func (mc *multiClient) Finish(ctx context.Context, data SpanData) error {
+	children := mc.childContexts(ctx)
+	done := make(chan error, len(children))
+	for c, cctx := range children {
+		c, cctx := c, cctx
+		go func() {
+			done <- c.Finish(cctx, data)
+		}()
+	}
+	return mc.combine(len(children), done)
+}
+
+// Close closes every underlying Client that implements io.Closer. It is not
+// part of the Client interface; callers that know they hold a MultiClient
+// can type-assert for it to shut every backend down together.
+func (mc *multiClient) Close() error {
+	var closers []interface{ Close() error }
+	for _, c := range mc.clients {
+		if cl, ok := c.(interface{ Close() error }); ok {
+			closers = append(closers, cl)
+		}
+	}
+	done := make(chan error, len(closers))
+	for _, cl := range closers {
+		cl := cl
+		go func() {
+			done <- cl.Close()
+		}()
+	}
+	return mc.combine(len(closers), done)
+}
+
+// childContexts returns the per-child contexts stashed by NewSpan, since
+// each underlying Client keeps its own span identifiers in its own context
+// value. If ctx wasn't produced by this MultiClient's NewSpan, every client
+// is called with ctx directly.
+func (mc *multiClient) childContexts(ctx context.Context) map[Client]context.Context {
+	if v, ok := ctx.Value(multiSpanKey).(*spanContexts); ok {
+		return v.snapshot()
+	}
+	m := make(map[Client]context.Context, len(mc.clients))
+	for _, c := range mc.clients {
+		m[c] = ctx
+	}
+	return m
+}
+
+// combine waits for up to n results on errs, honoring the configured call
+// timeout, and folds them into a single error according to mode.
+func (mc *multiClient) combine(n int, errs <-chan error) error {
+	var collected []string
+	deadline := mc.deadline()
+	for i := 0; i < n; i++ {
+		select {
+		case err := <-errs:
+			if err == nil {
+				continue
+			}
+			if mc.mode == FailFast {
+				return err
+			}
+			collected = append(collected, err.Error())
+		case <-deadline:
+			return asError(collected)
+		}
+	}
+	return asError(collected)
+}
+
+// deadline returns a channel that fires once the configured call timeout
+// elapses, or nil (never fires) if no timeout is configured.
+func (mc *multiClient) deadline() <-chan time.Time {
+	if mc.timeout <= 0 {
+		return nil
+	}
+	return time.After(mc.timeout)
+}
+
+func asError(msgs []string) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	return multiError(msgs)
+}
+
+// multiError combines the errors returned by several underlying Clients
+// into one.
+type multiError []string
+
+func (m multiError) Error() string {
+	return strings.Join([]string(m), "; ")
+}
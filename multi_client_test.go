@@ -0,0 +1,118 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClient is a minimal Client used to exercise MultiClient without a
+// real tracing backend.
+type fakeClient struct {
+	mu       sync.Mutex
+	finished int
+
+	newSpanDelay time.Duration
+	delay        time.Duration
+	finishErr    error
+}
+
+func (f *fakeClient) NewSpan(ctx context.Context, name string) context.Context {
+	if f.newSpanDelay > 0 {
+		time.Sleep(f.newSpanDelay)
+	}
+	return context.WithValue(ctx, contextKey("fake-span"), name)
+}
+
+func (f *fakeClient) Info(ctx context.Context) []byte { return nil }
+
+func (f *fakeClient) Finish(ctx context.Context, data SpanData) error {
+	if f.delay > 0 {
+		time.Sleep(f.delay)
+	}
+	f.mu.Lock()
+	f.finished++
+	f.mu.Unlock()
+	return f.finishErr
+}
+
+func TestMultiClientDispatchesToAllClients(t *testing.T) {
+	a, b, c := &fakeClient{}, &fakeClient{}, &fakeClient{}
+	mc := MultiClient([]Client{a, b, c})
+
+	ctx := WithClient(context.Background(), mc)
+	ctx, finish := WithSpan(ctx, "op")
+	if err := finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	_ = ctx
+
+	for i, cl := range []*fakeClient{a, b, c} {
+		if cl.finished != 1 {
+			t.Errorf("client %d: got %d Finish calls, want 1", i, cl.finished)
+		}
+	}
+}
+
+func TestMultiClientContinueOnErrorCombinesErrors(t *testing.T) {
+	a := &fakeClient{finishErr: errors.New("backend a down")}
+	b := &fakeClient{}
+	mc := MultiClient([]Client{a, b}, WithErrorMode(ContinueOnError))
+
+	ctx := WithClient(context.Background(), mc)
+	ctx, finish := WithSpan(ctx, "op")
+	_ = ctx
+	err := finish()
+	if err == nil {
+		t.Fatal("finish: got nil error, want combined error")
+	}
+	if b.finished != 1 {
+		t.Errorf("healthy client: got %d Finish calls, want 1", b.finished)
+	}
+}
+
+func TestMultiClientTimeoutDoesNotBlockOnSlowBackend(t *testing.T) {
+	slow := &fakeClient{delay: 200 * time.Millisecond}
+	fast := &fakeClient{}
+	mc := MultiClient([]Client{slow, fast}, WithCallTimeout(20*time.Millisecond))
+
+	ctx := WithClient(context.Background(), mc)
+	ctx, finish := WithSpan(ctx, "op")
+	_ = ctx
+
+	start := time.Now()
+	finish()
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("finish took %v, want well under the slow backend's %v delay", elapsed, slow.delay)
+	}
+	if fast.finished != 1 {
+		t.Error("fast client: Finish was not called")
+	}
+}
+
+func TestMultiClientSlowNewSpanIsStillFinished(t *testing.T) {
+	slow := &fakeClient{newSpanDelay: 100 * time.Millisecond}
+	fast := &fakeClient{}
+	mc := MultiClient([]Client{slow, fast}, WithCallTimeout(10*time.Millisecond))
+
+	ctx := WithClient(context.Background(), mc)
+	ctx, finish := WithSpan(ctx, "op")
+	_ = ctx
+
+	// Give the slow backend's NewSpan time to actually complete, well past
+	// the call timeout that NewSpan itself had to respect, before the span
+	// is finished.
+	time.Sleep(150 * time.Millisecond)
+
+	if err := finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if slow.finished != 1 {
+		t.Error("slow client: Finish was not called; its NewSpan result was dropped after the call timeout")
+	}
+	if fast.finished != 1 {
+		t.Error("fast client: Finish was not called")
+	}
+}
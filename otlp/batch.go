@@ -0,0 +1,102 @@
+package otlp
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchSize     = 512
+	defaultFlushInterval = 5 * time.Second
+	defaultQueueSize     = 2048
+)
+
+// batcher buffers converted spans and flushes them to an exporter either
+// when batchSize spans have queued up or flushInterval has elapsed,
+// whichever comes first. The queue is bounded at queueSize; once full, the
+// oldest queued span is dropped to make room for new ones rather than
+// blocking the caller or growing without limit.
+type batcher struct {
+	batchSize     int
+	flushInterval time.Duration
+	queueSize     int
+
+	mu      sync.Mutex
+	queue   []*otlpSpan
+	dropped int
+
+	flush   chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newBatcher() *batcher {
+	return &batcher{
+		batchSize:     defaultBatchSize,
+		flushInterval: defaultFlushInterval,
+		queueSize:     defaultQueueSize,
+		flush:         make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+	}
+}
+
+func (b *batcher) add(s *otlpSpan) {
+	b.mu.Lock()
+	if len(b.queue) >= b.queueSize {
+		b.queue = b.queue[1:]
+		b.dropped++
+	}
+	b.queue = append(b.queue, s)
+	full := len(b.queue) >= b.batchSize
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *batcher) take() []*otlpSpan {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.queue) == 0 {
+		return nil
+	}
+	batch := b.queue
+	b.queue = nil
+	return batch
+}
+
+func (b *batcher) start(exp exporter) {
+	go func() {
+		defer close(b.stopped)
+		ticker := time.NewTicker(b.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-b.flush:
+			case <-b.done:
+				if batch := b.take(); len(batch) > 0 {
+					exp.Export(batch)
+				}
+				return
+			}
+			if batch := b.take(); len(batch) > 0 {
+				exp.Export(batch)
+			}
+		}
+	}()
+}
+
+// stop signals the drain goroutine to flush whatever is queued and exit,
+// and blocks until it has actually done so. Close relies on this: the
+// exporter must not be torn down while a final flush is still in flight,
+// or the last batched spans are silently dropped.
+func (b *batcher) stop() {
+	close(b.done)
+	<-b.stopped
+}
@@ -0,0 +1,106 @@
+package otlp
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeExporter records every batch passed to Export.
+type fakeExporter struct {
+	mu      sync.Mutex
+	batches [][]*otlpSpan
+	closed  bool
+}
+
+func (f *fakeExporter) Export(spans []*otlpSpan) error {
+	f.mu.Lock()
+	f.batches = append(f.batches, spans)
+	f.mu.Unlock()
+	return nil
+}
+
+func (f *fakeExporter) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeExporter) total() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, b := range f.batches {
+		n += len(b)
+	}
+	return n
+}
+
+func TestBatcherFlushesOnBatchSize(t *testing.T) {
+	b := newBatcher()
+	b.batchSize = 2
+	b.flushInterval = time.Hour
+	exp := &fakeExporter{}
+	b.start(exp)
+	defer b.stop()
+
+	b.add(&otlpSpan{name: "a"})
+	b.add(&otlpSpan{name: "b"})
+
+	deadline := time.After(time.Second)
+	for exp.total() < 2 {
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for batch to flush")
+		default:
+		}
+	}
+}
+
+func TestBatcherFlushesOnStop(t *testing.T) {
+	b := newBatcher()
+	b.flushInterval = time.Hour
+	exp := &fakeExporter{}
+	b.start(exp)
+
+	b.add(&otlpSpan{name: "a"})
+	b.stop()
+
+	if got := exp.total(); got != 1 {
+		t.Errorf("exported spans = %d, want 1", got)
+	}
+}
+
+func TestBatcherStopBlocksUntilDrainGoroutineExits(t *testing.T) {
+	b := newBatcher()
+	b.flushInterval = time.Hour
+	exp := &fakeExporter{}
+	b.start(exp)
+
+	b.add(&otlpSpan{name: "a"})
+	b.stop()
+
+	// stop must not return until the drain goroutine's final flush has
+	// already been observed by the exporter.
+	if got := exp.total(); got != 1 {
+		t.Errorf("exported spans after stop = %d, want 1 (stop returned before the final flush completed)", got)
+	}
+}
+
+func TestBatcherDropsOldestWhenQueueFull(t *testing.T) {
+	b := newBatcher()
+	b.queueSize = 2
+	b.add(&otlpSpan{name: "a"})
+	b.add(&otlpSpan{name: "b"})
+	b.add(&otlpSpan{name: "c"})
+
+	batch := b.take()
+	if len(batch) != 2 {
+		t.Fatalf("queued spans = %d, want 2", len(batch))
+	}
+	if batch[0].name != "b" || batch[1].name != "c" {
+		t.Errorf("queue = %v, want [b c] (oldest should have been dropped)", batch)
+	}
+	if b.dropped != 1 {
+		t.Errorf("dropped = %d, want 1", b.dropped)
+	}
+}
@@ -0,0 +1,153 @@
+// Package otlp implements trace.Client on top of an OTLP exporter, so
+// spans recorded with this module can be shipped to Jaeger, Tempo, or any
+// other OpenTelemetry collector, alongside or instead of the gcp backend.
+package otlp
+
+import (
+	"context"
+	"time"
+
+	trace "github.com/rakyll/trace2"
+)
+
+// Option configures a Client returned by NewClient.
+type Option func(*Client)
+
+// WithBatchSize sets how many spans a batch holds before it is flushed.
+// The default is 512.
+func WithBatchSize(n int) Option {
+	return func(c *Client) { c.batcher.batchSize = n }
+}
+
+// WithFlushInterval sets how often a partially-filled batch is flushed.
+// The default is 5 seconds.
+func WithFlushInterval(d time.Duration) Option {
+	return func(c *Client) { c.batcher.flushInterval = d }
+}
+
+// WithQueueSize bounds the number of spans buffered ahead of the exporter.
+// Once full, the oldest queued span is dropped to make room for new ones.
+// The default is 2048.
+func WithQueueSize(n int) Option {
+	return func(c *Client) { c.batcher.queueSize = n }
+}
+
+// WithHeaders sets extra headers sent with every export request, e.g. for
+// collector authentication.
+func WithHeaders(h map[string]string) Option {
+	return func(c *Client) { c.headers = h }
+}
+
+// WithInsecure disables TLS for an OTLP/gRPC connection. By default NewClient
+// dials a gRPC endpoint over TLS. It has no effect on an OTLP/HTTP endpoint,
+// whose "http://" or "https://" scheme alone decides whether TLS is used.
+func WithInsecure() Option {
+	return func(c *Client) { c.insecure = true }
+}
+
+// WithCompression gzip-compresses export requests.
+func WithCompression() Option {
+	return func(c *Client) { c.compress = true }
+}
+
+// Client is a trace.Client that batches spans and exports them to an OTLP
+// collector over gRPC or HTTP, depending on endpoint's scheme.
+type Client struct {
+	exporter exporter
+	batcher  *batcher
+
+	headers  map[string]string
+	insecure bool
+	compress bool
+}
+
+// NewClient dials endpoint and returns a Client that batches and exports
+// spans to it in the OTLP format. endpoint's scheme picks the transport:
+// "http://" or "https://" uses OTLP/HTTP, anything else (including a bare
+// host:port) is dialed as OTLP/gRPC.
+func NewClient(ctx context.Context, endpoint string, opts ...Option) (*Client, error) {
+	c := &Client{
+		batcher: newBatcher(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	exp, err := newExporter(ctx, endpoint, c.insecure, c.compress, c.headers)
+	if err != nil {
+		return nil, err
+	}
+	c.exporter = exp
+	c.batcher.start(exp)
+	return c, nil
+}
+
+type spanKeyType struct{}
+
+var spanKey spanKeyType
+
+// span is the bookkeeping otlp keeps in the context for a span between
+// NewSpan and Finish.
+type span struct {
+	traceID      [16]byte
+	spanID       [8]byte
+	parentSpanID [8]byte
+	name         string
+	start        time.Time
+}
+
+func (c *Client) NewSpan(ctx context.Context, name string) context.Context {
+	s := &span{
+		spanID: newSpanID(),
+		name:   name,
+		start:  time.Now(),
+	}
+	if parent, ok := ctx.Value(spanKey).(*span); ok {
+		s.traceID = parent.traceID
+		s.parentSpanID = parent.spanID
+	} else if sc, ok := trace.SpanContextFromContext(ctx); ok {
+		s.traceID = sc.TraceID
+		s.parentSpanID = sc.SpanID
+	} else {
+		s.traceID = newTraceID()
+	}
+	return context.WithValue(ctx, spanKey, s)
+}
+
+func (c *Client) Info(ctx context.Context) []byte {
+	s, ok := ctx.Value(spanKey).(*span)
+	if !ok {
+		return nil
+	}
+	return s.traceID[:]
+}
+
+func (c *Client) Finish(ctx context.Context, data trace.SpanData) error {
+	s, ok := ctx.Value(spanKey).(*span)
+	if !ok {
+		return nil
+	}
+	c.batcher.add(convertSpan(s, data, time.Now()))
+	return nil
+}
+
+// SpanContext returns the identifiers of the span in ctx, implementing
+// trace.SpanContextProvider so a Propagator can inject them into an
+// outgoing request.
+func (c *Client) SpanContext(ctx context.Context) (trace.SpanContext, bool) {
+	s, ok := ctx.Value(spanKey).(*span)
+	if !ok {
+		return trace.SpanContext{}, false
+	}
+	return trace.SpanContext{
+		TraceID: s.traceID,
+		SpanID:  s.spanID,
+		Sampled: trace.Sampled(ctx),
+	}, true
+}
+
+// Close flushes any batched spans and closes the underlying exporter.
+func (c *Client) Close() error {
+	c.batcher.stop()
+	return c.exporter.Close()
+}
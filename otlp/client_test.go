@@ -0,0 +1,96 @@
+package otlp
+
+import (
+	"context"
+	"testing"
+
+	trace "github.com/rakyll/trace2"
+)
+
+func TestClientNewSpanStartsRootTrace(t *testing.T) {
+	c := &Client{batcher: newBatcher()}
+	ctx := c.NewSpan(context.Background(), "op")
+
+	sc, ok := c.SpanContext(ctx)
+	if !ok {
+		t.Fatal("SpanContext: ok = false")
+	}
+	if sc.TraceID == ([16]byte{}) || sc.SpanID == ([8]byte{}) {
+		t.Error("SpanContext returned a zero trace or span ID")
+	}
+}
+
+func TestClientNewSpanInheritsParentTraceID(t *testing.T) {
+	c := &Client{batcher: newBatcher()}
+	rootCtx := c.NewSpan(context.Background(), "root")
+	rootSC, _ := c.SpanContext(rootCtx)
+
+	childCtx := c.NewSpan(rootCtx, "child")
+	childSC, _ := c.SpanContext(childCtx)
+
+	if childSC.TraceID != rootSC.TraceID {
+		t.Error("child span's TraceID doesn't match its parent's")
+	}
+	if childSC.SpanID == rootSC.SpanID {
+		t.Error("child span reused its parent's SpanID")
+	}
+}
+
+func TestClientNewSpanHonorsExtractedSpanContext(t *testing.T) {
+	c := &Client{batcher: newBatcher()}
+	var remote trace.SpanContext
+	remote.TraceID = newTraceID()
+	remote.SpanID = newSpanID()
+
+	ctx := trace.ContextWithSpanContext(context.Background(), remote)
+	ctx = c.NewSpan(ctx, "op")
+
+	sc, _ := c.SpanContext(ctx)
+	if sc.TraceID != remote.TraceID {
+		t.Error("NewSpan didn't adopt the extracted SpanContext's TraceID")
+	}
+	s := ctx.Value(spanKey).(*span)
+	if s.parentSpanID != remote.SpanID {
+		t.Error("NewSpan didn't record the extracted SpanContext's SpanID as its parent")
+	}
+}
+
+func TestClientFinishQueuesSpanForExport(t *testing.T) {
+	exp := &fakeExporter{}
+	b := newBatcher()
+	b.start(exp)
+	c := &Client{batcher: b}
+
+	ctx := c.NewSpan(context.Background(), "op")
+	if err := c.Finish(ctx, trace.SpanData{}); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	b.stop()
+
+	if got := exp.total(); got != 1 {
+		t.Errorf("exported spans = %d, want 1", got)
+	}
+}
+
+func TestClientInfoReturnsTraceID(t *testing.T) {
+	c := &Client{batcher: newBatcher()}
+	ctx := c.NewSpan(context.Background(), "op")
+	sc, _ := c.SpanContext(ctx)
+
+	info := c.Info(ctx)
+	if len(info) != 16 {
+		t.Fatalf("Info returned %d bytes, want 16", len(info))
+	}
+	var got [16]byte
+	copy(got[:], info)
+	if got != sc.TraceID {
+		t.Error("Info didn't return the span's TraceID")
+	}
+}
+
+func TestClientSpanContextWithoutSpanReturnsFalse(t *testing.T) {
+	c := &Client{batcher: newBatcher()}
+	if _, ok := c.SpanContext(context.Background()); ok {
+		t.Error("SpanContext: ok = true for a context with no span")
+	}
+}
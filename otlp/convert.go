@@ -0,0 +1,68 @@
+package otlp
+
+import (
+	"time"
+
+	trace "github.com/rakyll/trace2"
+)
+
+// otlpSpan is this package's span representation, already shaped close to
+// an OTLP ResourceSpans entry. exporters translate it into the wire format
+// their transport expects.
+type otlpSpan struct {
+	traceID      [16]byte
+	spanID       [8]byte
+	parentSpanID [8]byte
+	name         string
+	kind         trace.SpanKind
+	start        time.Time
+	end          time.Time
+	attrs        map[string]interface{}
+	statusCode   trace.Code
+	statusMsg    string
+	events       []trace.Event
+}
+
+func convertSpan(s *span, data trace.SpanData, end time.Time) *otlpSpan {
+	return &otlpSpan{
+		traceID:      s.traceID,
+		spanID:       s.spanID,
+		parentSpanID: s.parentSpanID,
+		name:         s.name,
+		kind:         data.Kind,
+		start:        s.start,
+		end:          end,
+		attrs:        data.Labels,
+		statusCode:   data.Code,
+		statusMsg:    data.Message,
+		events:       data.Events,
+	}
+}
+
+// otlpSpanKind maps this module's SpanKind onto the OTLP/OpenTelemetry
+// SpanKind enum values (SPAN_KIND_UNSPECIFIED=0 through SPAN_KIND_CONSUMER=5).
+func otlpSpanKind(k trace.SpanKind) int32 {
+	switch k {
+	case trace.SpanKindInternal:
+		return 1
+	case trace.SpanKindServer:
+		return 2
+	case trace.SpanKindClient:
+		return 3
+	case trace.SpanKindProducer:
+		return 4
+	case trace.SpanKindConsumer:
+		return 5
+	default:
+		return 0
+	}
+}
+
+// otlpStatusCode maps this module's Code onto the OTLP Status.StatusCode
+// enum (STATUS_CODE_UNSET=0, STATUS_CODE_OK=1, STATUS_CODE_ERROR=2).
+func otlpStatusCode(c trace.Code) int32 {
+	if c == trace.CodeError {
+		return 2
+	}
+	return 1
+}
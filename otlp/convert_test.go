@@ -0,0 +1,67 @@
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	trace "github.com/rakyll/trace2"
+)
+
+func TestConvertSpan(t *testing.T) {
+	start := time.Now()
+	end := start.Add(time.Second)
+	s := &span{
+		traceID:      newTraceID(),
+		spanID:       newSpanID(),
+		parentSpanID: newSpanID(),
+		name:         "op",
+		start:        start,
+	}
+	data := trace.SpanData{
+		Kind:    trace.SpanKindServer,
+		Labels:  map[string]interface{}{"k": "v"},
+		Code:    trace.CodeError,
+		Message: "boom",
+	}
+
+	got := convertSpan(s, data, end)
+	if got.traceID != s.traceID || got.spanID != s.spanID || got.parentSpanID != s.parentSpanID {
+		t.Error("convertSpan did not carry over the span's identifiers")
+	}
+	if got.name != s.name || got.start != start || got.end != end {
+		t.Error("convertSpan did not carry over name/start/end")
+	}
+	if got.kind != data.Kind || got.statusCode != data.Code || got.statusMsg != data.Message {
+		t.Error("convertSpan did not carry over the span data")
+	}
+	if got.attrs["k"] != "v" {
+		t.Error("convertSpan did not carry over labels")
+	}
+}
+
+func TestOtlpSpanKind(t *testing.T) {
+	cases := []struct {
+		in   trace.SpanKind
+		want int32
+	}{
+		{trace.SpanKindInternal, 1},
+		{trace.SpanKindServer, 2},
+		{trace.SpanKindClient, 3},
+		{trace.SpanKindProducer, 4},
+		{trace.SpanKindConsumer, 5},
+	}
+	for _, c := range cases {
+		if got := otlpSpanKind(c.in); got != c.want {
+			t.Errorf("otlpSpanKind(%v) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
+
+func TestOtlpStatusCode(t *testing.T) {
+	if got := otlpStatusCode(trace.CodeError); got != 2 {
+		t.Errorf("otlpStatusCode(CodeError) = %d, want 2", got)
+	}
+	if got := otlpStatusCode(trace.CodeOK); got != 1 {
+		t.Errorf("otlpStatusCode(CodeOK) = %d, want 1", got)
+	}
+}
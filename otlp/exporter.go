@@ -0,0 +1,20 @@
+package otlp
+
+import (
+	"context"
+	"strings"
+)
+
+// exporter sends a batch of spans to an OTLP collector. It is implemented
+// by grpcExporter and httpExporter.
+type exporter interface {
+	Export(spans []*otlpSpan) error
+	Close() error
+}
+
+func newExporter(ctx context.Context, endpoint string, insecure, compress bool, headers map[string]string) (exporter, error) {
+	if strings.HasPrefix(endpoint, "http://") || strings.HasPrefix(endpoint, "https://") {
+		return newHTTPExporter(endpoint, compress, headers)
+	}
+	return newGRPCExporter(ctx, endpoint, insecure, compress, headers)
+}
@@ -0,0 +1,83 @@
+package otlp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// httpExporter exports spans over OTLP/HTTP, POSTing a protobuf-encoded
+// ExportTraceServiceRequest to endpoint + "/v1/traces".
+type httpExporter struct {
+	endpoint string
+	client   *http.Client
+	headers  map[string]string
+	compress bool
+}
+
+func newHTTPExporter(endpoint string, compress bool, headers map[string]string) (*httpExporter, error) {
+	return &httpExporter{
+		endpoint: endpoint + "/v1/traces",
+		client:   &http.Client{},
+		headers:  headers,
+		compress: compress,
+	}, nil
+}
+
+func (e *httpExporter) Export(spans []*otlpSpan) error {
+	req := &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{resourceSpans(spans)},
+	}
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader = bytes.NewReader(body)
+	var encoding string
+	if e.compress {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		if _, err := gw.Write(body); err != nil {
+			return err
+		}
+		if err := gw.Close(); err != nil {
+			return err
+		}
+		reader = &buf
+		encoding = "gzip"
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, e.endpoint, reader)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	if encoding != "" {
+		httpReq.Header.Set("Content-Encoding", encoding)
+	}
+	for k, v := range e.headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp: export failed with status %s", resp.Status)
+	}
+	return nil
+}
+
+func (e *httpExporter) Close() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,34 @@
+package otlp
+
+import (
+	"crypto/rand"
+
+	trace "github.com/rakyll/trace2"
+)
+
+// newTraceID returns a random 16-byte trace ID, as required by the W3C
+// trace-context spec that OTLP's IDs follow.
+func newTraceID() [16]byte {
+	var id [16]byte
+	for {
+		rand.Read(id[:])
+		if id != ([16]byte{}) {
+			return id
+		}
+	}
+}
+
+// newSpanID returns a random 8-byte span ID.
+func newSpanID() [8]byte {
+	var id [8]byte
+	for {
+		rand.Read(id[:])
+		if id != ([8]byte{}) {
+			return id
+		}
+	}
+}
+
+// spanContext mirrors trace.SpanContext's byte widths so that IDs minted
+// here interoperate with the traceparent Propagator without conversion.
+type spanContext = trace.SpanContext
@@ -0,0 +1,25 @@
+package otlp
+
+import "testing"
+
+func TestNewTraceIDIsNonZeroAndRandom(t *testing.T) {
+	a := newTraceID()
+	b := newTraceID()
+	if a == ([16]byte{}) {
+		t.Fatal("newTraceID returned the zero ID")
+	}
+	if a == b {
+		t.Fatal("two calls to newTraceID returned the same ID")
+	}
+}
+
+func TestNewSpanIDIsNonZeroAndRandom(t *testing.T) {
+	a := newSpanID()
+	b := newSpanID()
+	if a == ([8]byte{}) {
+		t.Fatal("newSpanID returned the zero ID")
+	}
+	if a == b {
+		t.Fatal("two calls to newSpanID returned the same ID")
+	}
+}
@@ -0,0 +1,74 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+)
+
+// SpanContext carries the identifiers needed to continue a trace in another
+// process. TraceID and SpanID follow the W3C trace-context byte lengths (a
+// 16-byte trace ID and an 8-byte span ID) so that Propagator implementations
+// can convert between formats without loss.
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Sampled bool
+}
+
+// IsValid reports whether sc has a non-zero trace ID, i.e. whether it was
+// actually extracted from or set on a request rather than being the zero
+// value.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != [16]byte{}
+}
+
+// Propagator injects and extracts a SpanContext across process boundaries
+// using HTTP headers. Implementations are provided by the propagation
+// subpackage for Google's X-Cloud-Trace-Context, W3C traceparent/tracestate,
+// and B3 formats.
+type Propagator interface {
+	// Inject writes sc into h.
+	Inject(sc SpanContext, h http.Header)
+
+	// Extract reads a SpanContext from h. It returns false if h carries no
+	// span context in the propagator's format.
+	Extract(h http.Header) (SpanContext, bool)
+}
+
+// SpanContextProvider is implemented by Client backends that can expose the
+// identifiers of the span held in ctx, so that a Propagator can inject them
+// into an outgoing request. Backends that don't implement it get no
+// propagation headers from ToHTTPReq and no trace_id/span_id pair from Log.
+type SpanContextProvider interface {
+	SpanContext(ctx context.Context) (SpanContext, bool)
+}
+
+// PropagatorClient is implemented by Client backends that have a preferred
+// wire format for propagating trace context, such as a backend tied to a
+// specific vendor's header. WithPropagator overrides this preference.
+type PropagatorClient interface {
+	Propagator() Propagator
+}
+
+// WithPropagator overrides the Propagator used by HTTPHandler, taking
+// precedence over any preference the Client declares via PropagatorClient.
+func WithPropagator(p Propagator) Option {
+	return func(info *traceInfo) {
+		info.propagator = p
+	}
+}
+
+func propagatorFromContext(ctx context.Context) Propagator {
+	v := ctx.Value(traceInfoKey)
+	if v == nil {
+		return nil
+	}
+	info := v.(*traceInfo)
+	if info.propagator != nil {
+		return info.propagator
+	}
+	if pc, ok := info.client.(PropagatorClient); ok {
+		return pc.Propagator()
+	}
+	return nil
+}
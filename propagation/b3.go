@@ -0,0 +1,95 @@
+package propagation
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	trace "github.com/rakyll/trace2"
+)
+
+// The headers used by the B3 multi-header format.
+const (
+	B3TraceIDHeader = "X-B3-TraceId"
+	B3SpanIDHeader  = "X-B3-SpanId"
+	B3SampledHeader = "X-B3-Sampled"
+
+	// B3SingleHeader is the single-header form: {traceid}-{spanid}-{sampled}.
+	B3SingleHeader = "b3"
+)
+
+type b3Format struct {
+	single bool
+}
+
+// B3 returns a Propagator for the B3 multi-header format
+// (X-B3-TraceId/X-B3-SpanId/X-B3-Sampled), as used by Zipkin.
+func B3() trace.Propagator {
+	return b3Format{single: false}
+}
+
+// B3Single returns a Propagator for the single-header B3 format
+// ("b3: {traceid}-{spanid}-{sampled}").
+func B3Single() trace.Propagator {
+	return b3Format{single: true}
+}
+
+func (f b3Format) Inject(sc trace.SpanContext, h http.Header) {
+	traceID := hex.EncodeToString(sc.TraceID[:])
+	spanID := hex.EncodeToString(sc.SpanID[:])
+	sampled := "0"
+	if sc.Sampled {
+		sampled = "1"
+	}
+	if f.single {
+		h.Set(B3SingleHeader, traceID+"-"+spanID+"-"+sampled)
+		return
+	}
+	h.Set(B3TraceIDHeader, traceID)
+	h.Set(B3SpanIDHeader, spanID)
+	h.Set(B3SampledHeader, sampled)
+}
+
+func (f b3Format) Extract(h http.Header) (trace.SpanContext, bool) {
+	if f.single {
+		return extractB3Single(h.Get(B3SingleHeader))
+	}
+	return extractB3Multi(h.Get(B3TraceIDHeader), h.Get(B3SpanIDHeader), h.Get(B3SampledHeader))
+}
+
+func extractB3Single(v string) (trace.SpanContext, bool) {
+	parts := strings.Split(v, "-")
+	if len(parts) < 2 {
+		return trace.SpanContext{}, false
+	}
+	sampled := ""
+	if len(parts) >= 3 {
+		sampled = parts[2]
+	}
+	return extractB3Multi(parts[0], parts[1], sampled)
+}
+
+func extractB3Multi(traceIDHex, spanIDHex, sampled string) (trace.SpanContext, bool) {
+	if traceIDHex == "" || spanIDHex == "" {
+		return trace.SpanContext{}, false
+	}
+	// B3 allows a 64-bit (16 hex char) trace ID; left-pad it into our
+	// 128-bit SpanContext.TraceID.
+	if len(traceIDHex) == 16 {
+		traceIDHex = strings.Repeat("0", 16) + traceIDHex
+	}
+	traceID, err := hex.DecodeString(traceIDHex)
+	if err != nil || len(traceID) != 16 {
+		return trace.SpanContext{}, false
+	}
+	spanID, err := hex.DecodeString(spanIDHex)
+	if err != nil || len(spanID) != 8 {
+		return trace.SpanContext{}, false
+	}
+
+	var sc trace.SpanContext
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Sampled = sampled == "1" || sampled == "d"
+	return sc, true
+}
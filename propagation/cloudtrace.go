@@ -0,0 +1,64 @@
+// Package propagation provides trace.Propagator implementations for the
+// wire formats commonly used to carry trace context across HTTP requests:
+// Google's X-Cloud-Trace-Context, W3C traceparent/tracestate, and B3.
+package propagation
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+
+	trace "github.com/rakyll/trace2"
+)
+
+// CloudTraceHeader is the header Stackdriver/Cloud Trace uses to propagate
+// trace context: "TRACE_ID/SPAN_ID;o=OPTIONS".
+const CloudTraceHeader = "X-Cloud-Trace-Context"
+
+type cloudTraceFormat struct{}
+
+// CloudTrace returns a Propagator for Google's X-Cloud-Trace-Context header.
+func CloudTrace() trace.Propagator {
+	return cloudTraceFormat{}
+}
+
+func (cloudTraceFormat) Inject(sc trace.SpanContext, h http.Header) {
+	opts := 0
+	if sc.Sampled {
+		opts = 1
+	}
+	spanID := uint64FromBytes(sc.SpanID)
+	h.Set(CloudTraceHeader, hex.EncodeToString(sc.TraceID[:])+"/"+strconv.FormatUint(spanID, 10)+";o="+strconv.Itoa(opts))
+}
+
+func (cloudTraceFormat) Extract(h http.Header) (trace.SpanContext, bool) {
+	v := h.Get(CloudTraceHeader)
+	if v == "" {
+		return trace.SpanContext{}, false
+	}
+	slashIdx := strings.IndexByte(v, '/')
+	if slashIdx < 0 {
+		return trace.SpanContext{}, false
+	}
+	traceIDHex, rest := v[:slashIdx], v[slashIdx+1:]
+	traceIDBytes, err := hex.DecodeString(traceIDHex)
+	if err != nil || len(traceIDBytes) != 16 {
+		return trace.SpanContext{}, false
+	}
+
+	spanIDDec, opts := rest, ""
+	if i := strings.IndexByte(rest, ';'); i >= 0 {
+		spanIDDec, opts = rest[:i], rest[i+1:]
+	}
+	spanID, err := strconv.ParseUint(spanIDDec, 10, 64)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	var sc trace.SpanContext
+	copy(sc.TraceID[:], traceIDBytes)
+	putUint64(sc.SpanID[:], spanID)
+	sc.Sampled = strings.TrimPrefix(opts, "o=") == "1"
+	return sc, true
+}
@@ -0,0 +1,106 @@
+package propagation
+
+import (
+	"net/http"
+	"testing"
+
+	trace "github.com/rakyll/trace2"
+)
+
+func testSpanContext() trace.SpanContext {
+	var sc trace.SpanContext
+	for i := range sc.TraceID {
+		sc.TraceID[i] = byte(i + 1)
+	}
+	for i := range sc.SpanID {
+		sc.SpanID[i] = byte(i + 1)
+	}
+	sc.Sampled = true
+	return sc
+}
+
+func TestPropagatorsRoundTrip(t *testing.T) {
+	want := testSpanContext()
+	for _, p := range []trace.Propagator{B3(), B3Single(), CloudTrace(), TraceContext()} {
+		h := make(http.Header)
+		p.Inject(want, h)
+		got, ok := p.Extract(h)
+		if !ok {
+			t.Errorf("%T: Extract returned ok=false after Inject", p)
+			continue
+		}
+		if got != want {
+			t.Errorf("%T: round trip = %+v, want %+v", p, got, want)
+		}
+	}
+}
+
+func TestPropagatorsRoundTripNotSampled(t *testing.T) {
+	want := testSpanContext()
+	want.Sampled = false
+	for _, p := range []trace.Propagator{B3(), B3Single(), CloudTrace(), TraceContext()} {
+		h := make(http.Header)
+		p.Inject(want, h)
+		got, ok := p.Extract(h)
+		if !ok {
+			t.Errorf("%T: Extract returned ok=false after Inject", p)
+			continue
+		}
+		if got.Sampled {
+			t.Errorf("%T: Sampled = true, want false", p)
+		}
+	}
+}
+
+func TestPropagatorsExtractMissingHeaders(t *testing.T) {
+	for _, p := range []trace.Propagator{B3(), B3Single(), CloudTrace(), TraceContext()} {
+		if _, ok := p.Extract(make(http.Header)); ok {
+			t.Errorf("%T: Extract with no headers returned ok=true", p)
+		}
+	}
+}
+
+func TestB3MultiAcceptsShortTraceID(t *testing.T) {
+	h := make(http.Header)
+	h.Set(B3TraceIDHeader, "0000000000000001")
+	h.Set(B3SpanIDHeader, "0000000000000002")
+	h.Set(B3SampledHeader, "1")
+
+	sc, ok := B3().Extract(h)
+	if !ok {
+		t.Fatal("Extract: ok=false")
+	}
+	if sc.TraceID != [16]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1} {
+		t.Errorf("TraceID = %x, want left-padded 64-bit ID", sc.TraceID)
+	}
+}
+
+func TestCloudTraceExtractMalformed(t *testing.T) {
+	cases := []string{
+		"",
+		"not-hex/1;o=1",
+		"0000000000000000000000000000000100", // missing "/span;o="
+	}
+	for _, v := range cases {
+		h := make(http.Header)
+		h.Set(CloudTraceHeader, v)
+		if _, ok := CloudTrace().Extract(h); ok {
+			t.Errorf("Extract(%q): ok=true, want false", v)
+		}
+	}
+}
+
+func TestTraceContextExtractMalformed(t *testing.T) {
+	cases := []string{
+		"",
+		"01-00000000000000000000000000000001-0000000000000001-01", // wrong version
+		"00-bad-0000000000000001-01",
+	}
+	for _, v := range cases {
+		h := make(http.Header)
+		h.Set(TraceParentHeader, v)
+		if _, ok := TraceContext().Extract(h); ok {
+			t.Errorf("Extract(%q): ok=true, want false", v)
+		}
+	}
+}
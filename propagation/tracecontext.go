@@ -0,0 +1,63 @@
+package propagation
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	trace "github.com/rakyll/trace2"
+)
+
+// TraceParentHeader and TraceStateHeader are the headers defined by the W3C
+// Trace Context recommendation.
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+
+	traceContextVersion = "00"
+)
+
+type traceContextFormat struct{}
+
+// TraceContext returns a Propagator for the W3C traceparent/tracestate
+// headers. tracestate is accepted on Extract but dropped on Inject, since
+// this package has no vendor-specific state to carry in it.
+func TraceContext() trace.Propagator {
+	return traceContextFormat{}
+}
+
+func (traceContextFormat) Inject(sc trace.SpanContext, h http.Header) {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	h.Set(TraceParentHeader, traceContextVersion+"-"+
+		hex.EncodeToString(sc.TraceID[:])+"-"+
+		hex.EncodeToString(sc.SpanID[:])+"-"+flags)
+}
+
+func (traceContextFormat) Extract(h http.Header) (trace.SpanContext, bool) {
+	v := h.Get(TraceParentHeader)
+	parts := strings.Split(v, "-")
+	if len(parts) != 4 || parts[0] != traceContextVersion {
+		return trace.SpanContext{}, false
+	}
+	traceID, err := hex.DecodeString(parts[1])
+	if err != nil || len(traceID) != 16 {
+		return trace.SpanContext{}, false
+	}
+	spanID, err := hex.DecodeString(parts[2])
+	if err != nil || len(spanID) != 8 {
+		return trace.SpanContext{}, false
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil || len(flags) != 1 {
+		return trace.SpanContext{}, false
+	}
+
+	var sc trace.SpanContext
+	copy(sc.TraceID[:], traceID)
+	copy(sc.SpanID[:], spanID)
+	sc.Sampled = flags[0]&0x1 == 1
+	return sc, true
+}
@@ -0,0 +1,11 @@
+package propagation
+
+import "encoding/binary"
+
+func uint64FromBytes(b [8]byte) uint64 {
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func putUint64(b []byte, v uint64) {
+	binary.BigEndian.PutUint64(b, v)
+}
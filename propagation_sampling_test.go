@@ -0,0 +1,40 @@
+package trace_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	trace "github.com/rakyll/trace2"
+	"github.com/rakyll/trace2/otlp"
+	"github.com/rakyll/trace2/propagation"
+)
+
+// TestToHTTPReqPropagatesUnsampledDecision reproduces the bug where an
+// unsampled span never got a SpanContext, so ToHTTPReq silently emitted no
+// propagation header at all instead of one that tells the downstream
+// service to honor this trace's "not sampled" decision.
+func TestToHTTPReqPropagatesUnsampledDecision(t *testing.T) {
+	c, err := otlp.NewClient(context.Background(), "otel-collector:4317", otlp.WithInsecure())
+	if err != nil {
+		t.Fatalf("otlp.NewClient: %v", err)
+	}
+	defer c.Close()
+
+	ctx := trace.WithClient(context.Background(), c,
+		trace.WithSampler(trace.NeverSample()),
+		trace.WithPropagator(propagation.TraceContext()))
+	ctx, finish := trace.WithSpan(ctx, "op")
+	defer finish()
+
+	req, _ := http.NewRequest("GET", "http://example.com/", nil)
+	req = trace.ToHTTPReq(ctx, req)
+
+	got := req.Header.Get(propagation.TraceParentHeader)
+	if got == "" {
+		t.Fatal("traceparent header is empty; the unsampled decision wasn't propagated at all")
+	}
+	if got[len(got)-2:] != "00" {
+		t.Errorf("traceparent = %q, want trailing sampled flag 00", got)
+	}
+}
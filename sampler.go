@@ -0,0 +1,122 @@
+package trace
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// SamplingDecision is the result of a Sampler's decision for a given span.
+type SamplingDecision struct {
+	// Sample reports whether the span should be recorded and reported to
+	// the tracing backend.
+	Sample bool
+}
+
+// Sampler decides whether a span should be sampled. WithSpan consults the
+// Sampler attached to the context (see WithSampler) before asking the
+// Client to create a new span.
+//
+// parentSampled is nil if name has no parent span in the current trace,
+// otherwise it points to whether the parent was sampled. Samplers that want
+// every span in a trace to share the same fate should honor parentSampled
+// when it is non-nil.
+type Sampler interface {
+	ShouldSample(ctx context.Context, name string, parentSampled *bool) SamplingDecision
+}
+
+type alwaysSample struct{}
+
+func (alwaysSample) ShouldSample(ctx context.Context, name string, parentSampled *bool) SamplingDecision {
+	return SamplingDecision{Sample: true}
+}
+
+// AlwaysSample returns a Sampler that samples every span. This is the
+// default used by WithClient when no sampler is configured, preserving the
+// unconditional-span behavior the package had before Samplers existed.
+func AlwaysSample() Sampler {
+	return alwaysSample{}
+}
+
+type neverSample struct{}
+
+func (neverSample) ShouldSample(ctx context.Context, name string, parentSampled *bool) SamplingDecision {
+	return SamplingDecision{Sample: false}
+}
+
+// NeverSample returns a Sampler that never samples a span.
+func NeverSample() Sampler {
+	return neverSample{}
+}
+
+// tokenBucket is a simple QPS limiter. It is unexported because
+// ProbabilityRateLimited is the only intended caller.
+type tokenBucket struct {
+	mu     sync.Mutex
+	tokens float64
+	max    float64
+	rate   float64
+	last   time.Time
+}
+
+func newTokenBucket(qps float64) *tokenBucket {
+	return &tokenBucket{
+		tokens: qps,
+		max:    qps,
+		rate:   qps,
+		last:   time.Now(),
+	}
+}
+
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+type probabilityRateLimited struct {
+	bucket *tokenBucket
+	prob   float64
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// ProbabilityRateLimited returns a Sampler for root spans (spans with no
+// parent in the current trace) that first caps the overall rate of new
+// traces to qps, then samples prob fraction of the traces that pass the
+// cap. Non-root spans always honor their parent's sampling decision so
+// that a whole trace is sampled consistently.
+func ProbabilityRateLimited(qps, prob float64) Sampler {
+	return &probabilityRateLimited{
+		bucket: newTokenBucket(qps),
+		prob:   prob,
+		rnd:    rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (s *probabilityRateLimited) ShouldSample(ctx context.Context, name string, parentSampled *bool) SamplingDecision {
+	if parentSampled != nil {
+		return SamplingDecision{Sample: *parentSampled}
+	}
+	if !s.bucket.allow() {
+		return SamplingDecision{Sample: false}
+	}
+	s.mu.Lock()
+	p := s.rnd.Float64()
+	s.mu.Unlock()
+	return SamplingDecision{Sample: p < s.prob}
+}
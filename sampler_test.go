@@ -0,0 +1,58 @@
+package trace
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAlwaysSample(t *testing.T) {
+	d := AlwaysSample().ShouldSample(context.Background(), "op", nil)
+	if !d.Sample {
+		t.Error("Sample = false, want true")
+	}
+}
+
+func TestNeverSample(t *testing.T) {
+	d := NeverSample().ShouldSample(context.Background(), "op", nil)
+	if d.Sample {
+		t.Error("Sample = true, want false")
+	}
+}
+
+func TestProbabilityRateLimitedHonorsParentSampled(t *testing.T) {
+	s := ProbabilityRateLimited(100, 0)
+	for _, parentSampled := range []bool{true, false} {
+		d := s.ShouldSample(context.Background(), "op", &parentSampled)
+		if d.Sample != parentSampled {
+			t.Errorf("parentSampled=%v: Sample = %v, want %v", parentSampled, d.Sample, parentSampled)
+		}
+	}
+}
+
+func TestProbabilityRateLimitedZeroProbNeverSamplesRoots(t *testing.T) {
+	s := ProbabilityRateLimited(100, 0)
+	for i := 0; i < 50; i++ {
+		if s.ShouldSample(context.Background(), "op", nil).Sample {
+			t.Fatal("Sample = true with prob=0")
+		}
+	}
+}
+
+func TestProbabilityRateLimitedOneProbAlwaysSamplesWithinQPS(t *testing.T) {
+	s := ProbabilityRateLimited(100, 1)
+	for i := 0; i < 50; i++ {
+		if !s.ShouldSample(context.Background(), "op", nil).Sample {
+			t.Fatal("Sample = false with prob=1 and qps not exceeded")
+		}
+	}
+}
+
+func TestTokenBucketCapsRate(t *testing.T) {
+	b := newTokenBucket(1)
+	if !b.allow() {
+		t.Fatal("first call: allow() = false, want true")
+	}
+	if b.allow() {
+		t.Fatal("second immediate call: allow() = true, want false (bucket should be drained)")
+	}
+}
@@ -0,0 +1,116 @@
+package trace
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// recordingClient is a minimal Client that records the SpanData passed to
+// every Finish call, keyed by span name, so tests can assert on what a
+// particular span reported.
+type recordingClient struct {
+	mu      map[string]SpanData
+	nameKey contextKey
+}
+
+func newRecordingClient() *recordingClient {
+	return &recordingClient{mu: make(map[string]SpanData), nameKey: contextKey("span-name")}
+}
+
+func (c *recordingClient) NewSpan(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, c.nameKey, name)
+}
+
+func (c *recordingClient) Info(ctx context.Context) []byte { return nil }
+
+func (c *recordingClient) Finish(ctx context.Context, data SpanData) error {
+	name, _ := ctx.Value(c.nameKey).(string)
+	c.mu[name] = data
+	return nil
+}
+
+func TestSetKindAndSetStatusAreReportedOnFinish(t *testing.T) {
+	c := newRecordingClient()
+	ctx := WithClient(context.Background(), c)
+	ctx, finish := WithSpan(ctx, "op")
+
+	SetKind(ctx, SpanKindClient)
+	SetStatus(ctx, CodeError, "boom")
+
+	if err := finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	data := c.mu["op"]
+	if data.Kind != SpanKindClient {
+		t.Errorf("Kind = %v, want %v", data.Kind, SpanKindClient)
+	}
+	if data.Code != CodeError || data.Message != "boom" {
+		t.Errorf("Code/Message = %v/%q, want %v/%q", data.Code, data.Message, CodeError, "boom")
+	}
+}
+
+func TestAddEventIsReportedOnFinish(t *testing.T) {
+	c := newRecordingClient()
+	ctx := WithClient(context.Background(), c)
+	ctx, finish := WithSpan(ctx, "op")
+
+	at := time.Unix(1700000000, 0)
+	AddEvent(ctx, "retry", map[string]interface{}{"attempt": 2}, at)
+
+	if err := finish(); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+
+	data := c.mu["op"]
+	if len(data.Events) != 1 {
+		t.Fatalf("Events = %v, want exactly one", data.Events)
+	}
+	ev := data.Events[0]
+	if ev.Name != "retry" || ev.Attrs["attempt"] != 2 || !ev.Time.Equal(at) {
+		t.Errorf("Event = %+v, want name=retry attempt=2 time=%v", ev, at)
+	}
+}
+
+func TestSetKindAndSetStatusDoNothingWithoutClient(t *testing.T) {
+	// Must not panic when the context carries no trace client.
+	SetKind(context.Background(), SpanKindClient)
+	SetStatus(context.Background(), CodeError, "boom")
+	AddEvent(context.Background(), "retry", nil, time.Now())
+}
+
+func TestNestedSpansDoNotClobberEachOther(t *testing.T) {
+	c := newRecordingClient()
+	ctx := WithClient(context.Background(), c)
+
+	parentCtx, finishParent := WithSpan(ctx, "parent")
+	SetKind(parentCtx, SpanKindServer)
+	SetLabel(parentCtx, "shared-key", "parent-value")
+
+	childCtx, finishChild := WithSpan(parentCtx, "child")
+	SetKind(childCtx, SpanKindClient)
+	SetLabel(childCtx, "shared-key", "child-value")
+	if err := finishChild(); err != nil {
+		t.Fatalf("finishChild: %v", err)
+	}
+	if err := finishParent(); err != nil {
+		t.Fatalf("finishParent: %v", err)
+	}
+
+	parent := c.mu["parent"]
+	child := c.mu["child"]
+
+	if parent.Kind != SpanKindServer {
+		t.Errorf("parent Kind = %v, want %v (clobbered by child's SetKind)", parent.Kind, SpanKindServer)
+	}
+	if parent.Labels["shared-key"] != "parent-value" {
+		t.Errorf("parent label = %v, want parent-value (clobbered by child's SetLabel)", parent.Labels["shared-key"])
+	}
+	if child.Kind != SpanKindClient {
+		t.Errorf("child Kind = %v, want %v", child.Kind, SpanKindClient)
+	}
+	if child.Labels["shared-key"] != "child-value" {
+		t.Errorf("child label = %v, want child-value", child.Labels["shared-key"])
+	}
+}
@@ -6,8 +6,10 @@ package trace
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"runtime"
+	"time"
 )
 
 // Client represents a client communicates with a tracing backend.
@@ -24,19 +26,79 @@ type Client interface {
 	// Info returns the unique trace identifier assigned to the current context's trace tree.
 	Info(ctx context.Context) []byte
 
-	// Finish finishes the span in the context with the given labels. Nil labels
-	// should be accepted.
-	Finish(ctx context.Context, labels map[string]interface{}) error
+	// Finish finishes the span in the context with the given data. The zero
+	// SpanData should be accepted.
+	Finish(ctx context.Context, data SpanData) error
+}
+
+// SpanKind describes the relationship between a span and a remote
+// counterpart, e.g. whether a span represents the client or the server side
+// of an RPC. It is set with SetKind and defaults to SpanKindUnspecified.
+type SpanKind int
+
+const (
+	SpanKindUnspecified SpanKind = iota
+	SpanKindServer
+	SpanKindClient
+	SpanKindProducer
+	SpanKindConsumer
+	SpanKindInternal
+)
+
+// Code is a span's terminal status, set with SetStatus. It follows the
+// coarse OK/error distinction most tracing backends report rather than a
+// full RPC status code space.
+type Code int
+
+const (
+	CodeOK Code = iota
+	CodeError
+)
+
+// Event is a timestamped, log-like occurrence recorded on a span with
+// AddEvent. Unlike Labels, which describe the span as a whole, Events
+// capture things that happened at a specific point during the span.
+type Event struct {
+	Name  string
+	Attrs map[string]interface{}
+	Time  time.Time
+}
+
+// SpanData carries everything a Client needs to report about a finished
+// span.
+type SpanData struct {
+	Kind    SpanKind
+	Labels  map[string]interface{}
+	Code    Code
+	Message string
+	Events  []Event
+}
+
+// Option configures the trace info attached to a context by WithClient.
+type Option func(*traceInfo)
+
+// WithSampler sets the Sampler consulted by WithSpan before it asks the
+// Client to create a new span. If WithClient is called without a WithSampler option,
+// AlwaysSample is used, preserving the unconditional-span behavior the
+// package had before Samplers existed.
+func WithSampler(s Sampler) Option {
+	return func(info *traceInfo) {
+		info.sampler = s
+	}
 }
 
 // WithClient adds a Client into the current context later to be used to interact with
 // the tracing backend.
 //
 // All trace package functions will do nothing if this function is not called with a non-nil trace client.
-func WithClient(ctx context.Context, c Client) context.Context {
+func WithClient(ctx context.Context, c Client, opts ...Option) context.Context {
 	info := &traceInfo{
-		client: c,
-		labels: make(map[string]interface{}),
+		client:  c,
+		labels:  make(map[string]interface{}),
+		sampler: AlwaysSample(),
+	}
+	for _, opt := range opts {
+		opt(info)
 	}
 	return context.WithValue(ctx, traceInfoKey, info)
 }
@@ -67,12 +129,20 @@ type FinishFunc func() error
 // All the calls that is made by the returned span will be associated by the span created internally.
 //
 // If there is not trace client in the given context, WithSpan does nothing.
+//
+// Before creating the span, WithSpan consults the Sampler configured on the
+// context (see WithSampler). If the sampler decides against sampling, the
+// Client still creates the span, so that it has IDs to propagate, but the
+// returned FinishFunc is a no-op and the span is never reported; the decision
+// is recorded in the returned context so that nested WithSpan calls, and
+// anything that propagates the trace across process boundaries, honor it.
 func WithSpan(ctx context.Context, name string) (context.Context, FinishFunc) {
-	t := traceClientFromContext(ctx)
-	if t == nil {
+	info := infoFromContext(ctx)
+	if info == nil {
 		noop := func() error { return nil }
 		return ctx, noop
 	}
+	t := info.client
 	if name == "" {
 		// the name of the caller function
 		pc, _, _, ok := runtime.Caller(1)
@@ -81,17 +151,55 @@ func WithSpan(ctx context.Context, name string) (context.Context, FinishFunc) {
 			name = fn.Name()
 		}
 	}
+
+	var parentSampled *bool
+	if v, ok := ctx.Value(sampledKey).(bool); ok {
+		parentSampled = &v
+	}
+	decision := samplerFromContext(ctx).ShouldSample(ctx, name, parentSampled)
+	ctx = context.WithValue(ctx, sampledKey, decision.Sample)
+
+	// NewSpan still runs for an unsampled span so the backend mints real
+	// trace/span IDs for it: those IDs, not whether the span gets
+	// exported, are what ToHTTPReq propagates downstream, so a remote
+	// service can see this trace's sampled=false decision and honor it
+	// instead of starting a fresh, conflicting one.
 	newctx := t.NewSpan(ctx, name)
+	// Each span gets its own traceInfo so that SetKind/SetStatus/AddEvent/
+	// SetLabel calls made against this span don't clobber the data of its
+	// parent (or any sibling) span when they're finished.
+	spanInfo := newSpanInfo(info)
+	newctx = context.WithValue(newctx, traceInfoKey, spanInfo)
+	if !decision.Sample {
+		noop := func() error { return nil }
+		return newctx, noop
+	}
+
 	finish := func() error {
-		v := newctx.Value(traceInfoKey)
-		if v == nil {
-			return nil
-		}
-		return t.Finish(newctx, v.(*traceInfo).labels)
+		return t.Finish(newctx, SpanData{
+			Kind:    spanInfo.kind,
+			Labels:  spanInfo.labels,
+			Code:    spanInfo.code,
+			Message: spanInfo.message,
+			Events:  spanInfo.events,
+		})
 	}
 	return newctx, finish
 }
 
+// newSpanInfo returns a traceInfo for a new span, carrying forward the
+// trace-level configuration (client, sampler, propagator, logger) from
+// parent but starting with fresh, span-local labels/kind/status/events.
+func newSpanInfo(parent *traceInfo) *traceInfo {
+	return &traceInfo{
+		client:     parent.client,
+		labels:     make(map[string]interface{}),
+		sampler:    parent.sampler,
+		propagator: parent.propagator,
+		logger:     parent.logger,
+	}
+}
+
 type stringer struct {
 	format string
 	args   []interface{}
@@ -101,24 +209,138 @@ func (s *stringer) String() string {
 	return fmt.Sprintf(s.format, s.args...)
 }
 
-// TODO(jbd): Determine how Client can provide logging.
-
+// Logger receives the correlated log lines recorded with Log. Set one on a
+// context with WithLogger.
 type Logger interface {
 	Log(ctx context.Context, arg ...interface{}) error
 }
 
+// WithLogger sets the Logger that Log forwards correlated log lines to.
+func WithLogger(l Logger) Option {
+	return func(info *traceInfo) {
+		info.logger = l
+	}
+}
+
+// LoggingClient is implemented by Client backends that can receive log
+// lines on their own channel, separate from the Logger configured with
+// WithLogger -- for example a backend that also ships logs to Stackdriver
+// Logging.
+type LoggingClient interface {
+	Log(ctx context.Context, keyvals ...interface{}) error
+}
+
+// Log records a correlated log line: it tags keyvals with the current
+// span's trace_id and span_id (when the Client exposes them via
+// SpanContextProvider), adds it as a timed event on the current span so it
+// shows up inline in the trace UI, and forwards it to the Logger configured
+// with WithLogger and, if the Client implements LoggingClient, to the
+// backend's own logging channel too.
+//
+// If context doesn't contain a trace client, Log does nothing.
+func Log(ctx context.Context, keyvals ...interface{}) error {
+	info := infoFromContext(ctx)
+	if info == nil {
+		return nil
+	}
+
+	fields := make([]interface{}, 0, len(keyvals)+4)
+	if scp, ok := info.client.(SpanContextProvider); ok {
+		if sc, ok := scp.SpanContext(ctx); ok {
+			fields = append(fields,
+				"trace_id", hex.EncodeToString(sc.TraceID[:]),
+				"span_id", hex.EncodeToString(sc.SpanID[:]))
+		}
+	} else if id := info.client.Info(ctx); id != nil {
+		fields = append(fields, "trace_id", hex.EncodeToString(id))
+	}
+	fields = append(fields, keyvals...)
+
+	AddEvent(ctx, "log", keyvalsToAttrs(fields), time.Now())
+
+	var errs []string
+	if info.logger != nil {
+		if err := info.logger.Log(ctx, fields...); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if lc, ok := info.client.(LoggingClient); ok {
+		if err := lc.Log(ctx, fields...); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	return asError(errs)
+}
+
+func keyvalsToAttrs(keyvals []interface{}) map[string]interface{} {
+	attrs := make(map[string]interface{}, len(keyvals)/2)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		k, ok := keyvals[i].(string)
+		if !ok {
+			k = fmt.Sprintf("%v", keyvals[i])
+		}
+		attrs[k] = keyvals[i+1]
+	}
+	return attrs
+}
+
 // SetLabel sets label identified with key on the current span.
 //
 // If context doesn't contain a trace client, SetLabel does nothing.
 func SetLabel(ctx context.Context, key string, value interface{}) {
-	v := ctx.Value(traceInfoKey)
-	if v == nil {
+	info := infoFromContext(ctx)
+	if info == nil {
 		return
 	}
-	info := v.(*traceInfo)
 	info.labels[key] = value
 }
 
+// SetKind sets the kind of the current span, e.g. SpanKindServer for a span
+// representing an inbound RPC.
+//
+// If context doesn't contain a trace client, SetKind does nothing.
+func SetKind(ctx context.Context, kind SpanKind) {
+	info := infoFromContext(ctx)
+	if info == nil {
+		return
+	}
+	info.kind = kind
+}
+
+// SetStatus sets the terminal status of the current span, reported when the
+// span is finished.
+//
+// If context doesn't contain a trace client, SetStatus does nothing.
+func SetStatus(ctx context.Context, code Code, message string) {
+	info := infoFromContext(ctx)
+	if info == nil {
+		return
+	}
+	info.code = code
+	info.message = message
+}
+
+// AddEvent records a timestamped, log-like event on the current span. Use
+// it for things that happen at a point in time during the span, as opposed
+// to SetLabel, which describes the span as a whole.
+//
+// If context doesn't contain a trace client, AddEvent does nothing.
+func AddEvent(ctx context.Context, name string, attrs map[string]interface{}, at time.Time) {
+	info := infoFromContext(ctx)
+	if info == nil {
+		return
+	}
+	info.events = append(info.events, Event{Name: name, Attrs: attrs, Time: at})
+}
+
+func infoFromContext(ctx context.Context) *traceInfo {
+	v := ctx.Value(traceInfoKey)
+	if v == nil {
+		return nil
+	}
+	return v.(*traceInfo)
+}
+
 func traceClientFromContext(ctx context.Context) Client {
 	v := ctx.Value(traceInfoKey)
 	if v == nil {
@@ -127,13 +349,41 @@ func traceClientFromContext(ctx context.Context) Client {
 	return v.(*traceInfo).client
 }
 
+func samplerFromContext(ctx context.Context) Sampler {
+	v := ctx.Value(traceInfoKey)
+	if v == nil {
+		return AlwaysSample()
+	}
+	info := v.(*traceInfo)
+	if info.sampler == nil {
+		return AlwaysSample()
+	}
+	return info.sampler
+}
+
+// Sampled reports whether the span in ctx was sampled, i.e. whether the last
+// WithSpan call against this trace decided to record it. It returns false
+// if ctx carries no sampling decision yet.
+func Sampled(ctx context.Context) bool {
+	v, _ := ctx.Value(sampledKey).(bool)
+	return v
+}
+
 type contextKey string
 
 var (
 	traceInfoKey = contextKey("trace")
+	sampledKey   = contextKey("trace-sampled")
 )
 
 type traceInfo struct {
-	client Client
-	labels map[string]interface{}
+	client     Client
+	labels     map[string]interface{}
+	sampler    Sampler
+	propagator Propagator
+	kind       SpanKind
+	code       Code
+	message    string
+	events     []Event
+	logger     Logger
 }